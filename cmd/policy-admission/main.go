@@ -72,6 +72,18 @@ func main() {
 				Name:  "authorizer",
 				Usage: "enable a admission authorizer, the format is name=config_path (i.e securitycontext=config.yaml)",
 			},
+			cli.StringSliceFlag{
+				Name:  "always-allow-path",
+				Usage: "a http path prefix which should always be allowed, bypassing all authorizers",
+			},
+			cli.StringSliceFlag{
+				Name:  "always-allow-user",
+				Usage: "a username which should always be allowed, bypassing all authorizers",
+			},
+			cli.StringSliceFlag{
+				Name:  "dry-run-provider",
+				Usage: "run the named authorizer in dry-run mode, logging and counting denials without enforcing them",
+			},
 			cli.StringFlag{
 				Name:   "namespace",
 				Usage:  "namespace to create denial events (optional as we can try and discover) `NAME`",
@@ -88,6 +100,12 @@ func main() {
 				Usage:  "indicates you wish to log kubernetes events on denials `BOOL`",
 				EnvVar: "ENABLE_EVENTS",
 			},
+			cli.StringFlag{
+				Name:   "mode",
+				Usage:  "controls whether we stop on the first denial (fail-fast) or aggregate all denials (aggregate) `MODE`",
+				Value:  server.ModeFailFast,
+				EnvVar: "MODE",
+			},
 		},
 
 		Action: func(cx *cli.Context) error {
@@ -103,12 +121,16 @@ func main() {
 			}
 
 			config := &server.Config{
-				EnableEvents:  cx.Bool("enable-events"),
-				EnableLogging: cx.Bool("enable-logging"),
-				Listen:        cx.String("listen"),
-				Namespace:     cx.String("namespace"),
-				TLSCert:       cx.String("tls-cert"),
-				TLSKey:        cx.String("tls-key"),
+				AlwaysAllowPaths: cx.StringSlice("always-allow-path"),
+				AlwaysAllowUsers: cx.StringSlice("always-allow-user"),
+				DryRunProviders:  cx.StringSlice("dry-run-provider"),
+				EnableEvents:     cx.Bool("enable-events"),
+				EnableLogging:    cx.Bool("enable-logging"),
+				Listen:           cx.String("listen"),
+				Mode:             cx.String("mode"),
+				Namespace:        cx.String("namespace"),
+				TLSCert:          cx.String("tls-cert"),
+				TLSKey:           cx.String("tls-key"),
 			}
 
 			// @step: create the server
@@ -124,10 +146,16 @@ func main() {
 				os.Exit(1)
 			}
 
-			// @step setup the termination signals
-			signalChannel := make(chan os.Signal)
+			// @step setup the termination signals, SIGHUP is handled by the reloadable
+			// authorizers themselves and should not bring the service down
+			signalChannel := make(chan os.Signal, 1)
 			signal.Notify(signalChannel, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
-			<-signalChannel
+			for sig := range signalChannel {
+				if sig == syscall.SIGHUP {
+					continue
+				}
+				break
+			}
 
 			return nil
 		},
@@ -0,0 +1,78 @@
+/*
+Copyright 2017 Home Office All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"github.com/patrickmn/go-cache"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// FilterAll indicates the authorizer is interested in all kinds
+	FilterAll = "*"
+	// FilterIngresses indicates the authorizer is filtering on ingresses
+	FilterIngresses = "Ingress"
+	// FilterNamespace indicates the authorizer is filtering on namespaces
+	FilterNamespace = "Namespace"
+	// FilterPods indicates the authorizer is filtering on pods
+	FilterPods = "Pod"
+	// FilterServices indicates the authorizer is filtering on services
+	FilterServices = "Service"
+)
+
+// Filter is used by an authorizer to describe what it's interested in
+type Filter struct {
+	// IgnoreNamespaces is a list of namespaces the authorizer is not interested in
+	IgnoreNamespaces []string
+	// IgnoreOnFailure indicates we should ignore internal errors from this authorizer
+	IgnoreOnFailure bool
+	// Kind is the resource kind this authorizer is filtering on
+	Kind string
+}
+
+// PatchOperation is a RFC 6902 JSON patch operation returned by a Mutate call
+type PatchOperation struct {
+	// Op is the patch operation, i.e. add, remove, replace
+	Op string `json:"op"`
+	// Path is the json pointer path the operation applies to
+	Path string `json:"path"`
+	// Value is the value used by add and replace operations
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Authorize is the interface for an admission authorizer
+type Authorize interface {
+	// Admit is responsible for authorizing the object, any errors returned deny the request
+	Admit(kubernetes.Interface, *cache.Cache, v1.Object) field.ErrorList
+	// FilterOn returns the filter this authorizer is using
+	FilterOn() *Filter
+	// Name returns the name of the provider
+	Name() string
+}
+
+// Mutator is an optional interface an Authorize implementation can satisfy if it needs to
+// patch the object under review. It's only called after the same authorizer's Admit has
+// accepted the object - an authorizer which denies never reaches its own Mutate (in aggregate
+// mode a denial from one authorizer doesn't stop the others being mutated). Authorizers which don't implement
+// it are treated as a no-op mutator, so existing authorizers require no changes to keep
+// satisfying Authorize.
+type Mutator interface {
+	// Mutate is responsible for returning any patches required on the object
+	Mutate(kubernetes.Interface, *cache.Cache, v1.Object) ([]PatchOperation, field.ErrorList)
+}
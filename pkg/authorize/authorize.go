@@ -23,11 +23,13 @@ import (
 	"github.com/UKHomeOffice/policy-admission/pkg/authorize/domains"
 	"github.com/UKHomeOffice/policy-admission/pkg/authorize/imagelist"
 	"github.com/UKHomeOffice/policy-admission/pkg/authorize/images"
+	"github.com/UKHomeOffice/policy-admission/pkg/authorize/ingressowner"
 	"github.com/UKHomeOffice/policy-admission/pkg/authorize/kubecertmanager"
 	"github.com/UKHomeOffice/policy-admission/pkg/authorize/namespaces"
 	"github.com/UKHomeOffice/policy-admission/pkg/authorize/securitycontext"
 	"github.com/UKHomeOffice/policy-admission/pkg/authorize/services"
 	"github.com/UKHomeOffice/policy-admission/pkg/authorize/tolerations"
+	"github.com/UKHomeOffice/policy-admission/pkg/authorize/webhook"
 )
 
 // New creates and returns a provider
@@ -48,6 +50,8 @@ func newAuthorizer(name, path string) (api.Authorize, error) {
 		return images.NewFromFile(path)
 	case imagelist.Name:
 		return imagelist.NewFromFile(path)
+	case ingressowner.Name:
+		return ingressowner.NewFromFile(path)
 	case kubecertmanager.Name:
 		return kubecertmanager.NewFromFile(path)
 	case namespaces.Name:
@@ -58,6 +62,8 @@ func newAuthorizer(name, path string) (api.Authorize, error) {
 		return services.NewFromFile(path)
 	case tolerations.Name:
 		return tolerations.NewFromFile(path)
+	case webhook.Name:
+		return webhook.NewFromFile(path)
 	default:
 		return nil, errors.New("unsupported authorizer")
 	}
@@ -0,0 +1,187 @@
+/*
+Copyright 2017 Home Office All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressowner
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/UKHomeOffice/policy-admission/pkg/api"
+
+	"github.com/patrickmn/go-cache"
+	"gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
+	extensions "k8s.io/kubernetes/pkg/apis/extensions"
+)
+
+// Name is the name of the authorizer
+const Name = "ingressowner"
+
+// cacheKey is the key we cache the cluster-wide ingress listing under
+const cacheKey = "ingressowner/ingresses"
+
+// cacheExpiry is how long we cache the ingress listing for
+var cacheExpiry = 30 * time.Second
+
+// Binding asserts that only a namespace may own ingresses under a set of domains
+type Binding struct {
+	// Namespace is the namespace permitted to own the domains below
+	Namespace string `yaml:"namespace"`
+	// AllowedDomains is a list of domain suffixes the namespace owns
+	AllowedDomains []string `yaml:"allowed-domains"`
+}
+
+// Config is the configuration for the ingressowner authorizer
+type Config struct {
+	// Bindings is the list of namespace to domain ownership bindings
+	Bindings []Binding `yaml:"bindings"`
+}
+
+// authorizer is used to prevent ingresses claiming a host already owned by another namespace
+type authorizer struct {
+	config *Config
+}
+
+// NewFromFile creates and returns a ingressowner authorizer from a config file
+func NewFromFile(path string) (api.Authorize, error) {
+	config := &Config{}
+
+	if path != "" {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal(content, config); err != nil {
+			return nil, err
+		}
+	}
+
+	return &authorizer{config: config}, nil
+}
+
+// Admit is responsible for denying ingresses which collide with a host owned by another namespace
+func (a *authorizer) Admit(client kubernetes.Interface, cc *cache.Cache, object metav1.Object) field.ErrorList {
+	var errs field.ErrorList
+
+	ingress, ok := object.(*extensions.Ingress)
+	if !ok {
+		return append(errs, field.InternalError(field.NewPath(Name), fmt.Errorf("expected an ingress, got %T", object)))
+	}
+
+	existing, err := a.listIngresses(client, cc)
+	if err != nil {
+		return append(errs, field.InternalError(field.NewPath(Name), err))
+	}
+
+	for i, rule := range ingress.Spec.Rules {
+		if rule.Host == "" {
+			continue
+		}
+
+		owner, found := a.ownerOf(rule.Host)
+		if found && owner != ingress.Namespace {
+			errs = append(errs, field.Invalid(field.NewPath("spec", "rules").Index(i).Child("host"), rule.Host,
+				fmt.Sprintf("host is owned by namespace %s", owner)))
+
+			continue
+		}
+
+		for _, x := range existing {
+			if x.Namespace == ingress.Namespace {
+				continue
+			}
+			if hostClaimed(x, rule.Host) {
+				errs = append(errs, field.Invalid(field.NewPath("spec", "rules").Index(i).Child("host"), rule.Host,
+					fmt.Sprintf("host is already in use by ingress %s/%s", x.Namespace, x.Name)))
+			}
+		}
+	}
+
+	return errs
+}
+
+// ownerOf returns the namespace which owns the domain the host falls under, if any
+func (a *authorizer) ownerOf(host string) (string, bool) {
+	for _, binding := range a.config.Bindings {
+		for _, domain := range binding.AllowedDomains {
+			if host == domain || strings.HasSuffix(host, "."+domain) {
+				return binding.Namespace, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// hostClaimed checks whether the given ingress already claims the host
+func hostClaimed(ingress ingressSummary, host string) bool {
+	for _, h := range ingress.Hosts {
+		if h == host {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ingressSummary is a lightweight representation of an existing ingress used for comparison
+type ingressSummary struct {
+	Namespace string
+	Name      string
+	Hosts     []string
+}
+
+// listIngresses returns a cluster-wide summary of the existing ingresses, cached to reduce api load
+func (a *authorizer) listIngresses(client kubernetes.Interface, cc *cache.Cache) ([]ingressSummary, error) {
+	if v, found := cc.Get(cacheKey); found {
+		return v.([]ingressSummary), nil
+	}
+
+	list, err := client.ExtensionsV1beta1().Ingresses(metav1.NamespaceAll).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var summaries []ingressSummary
+	for _, x := range list.Items {
+		summary := ingressSummary{Namespace: x.Namespace, Name: x.Name}
+		for _, rule := range x.Spec.Rules {
+			if rule.Host != "" {
+				summary.Hosts = append(summary.Hosts, rule.Host)
+			}
+		}
+		summaries = append(summaries, summary)
+	}
+
+	cc.Set(cacheKey, summaries, cacheExpiry)
+
+	return summaries, nil
+}
+
+// FilterOn returns the filter for this authorizer
+func (a *authorizer) FilterOn() *api.Filter {
+	return &api.Filter{Kind: api.FilterIngresses}
+}
+
+// Name returns the name of the provider
+func (a *authorizer) Name() string {
+	return Name
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2017 Home Office All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingressowner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	extensions "k8s.io/kubernetes/pkg/apis/extensions"
+)
+
+func newTestAuthorizer() *authorizer {
+	return &authorizer{config: &Config{}}
+}
+
+func TestAdmitAllowsUniqueHost(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	a := newTestAuthorizer()
+
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"},
+		Spec:       extensions.IngressSpec{Rules: []extensions.IngressRule{{Host: "app.example.com"}}},
+	}
+
+	if errs := a.Admit(client, cache.New(time.Minute, time.Minute), ingress); len(errs) != 0 {
+		t.Fatalf("expected no errors, got: %v", errs)
+	}
+}
+
+// TestAdmitDeniesCrossNamespaceHostCollisionSameName ensures two ingresses in different
+// namespaces which happen to share the same name are still caught as a host collision -
+// the self-exclusion check must never be name-only.
+func TestAdmitDeniesCrossNamespaceHostCollisionSameName(t *testing.T) {
+	client := fake.NewSimpleClientset(&extensionsv1beta1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"},
+		Spec: extensionsv1beta1.IngressSpec{
+			Rules: []extensionsv1beta1.IngressRule{{Host: "app.example.com"}},
+		},
+	})
+	a := newTestAuthorizer()
+
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-b"},
+		Spec:       extensions.IngressSpec{Rules: []extensions.IngressRule{{Host: "app.example.com"}}},
+	}
+
+	errs := a.Admit(client, cache.New(time.Minute, time.Minute), ingress)
+	if len(errs) != 1 {
+		t.Fatalf("expected the host collision to be denied, got: %v", errs)
+	}
+}
+
+func TestAdmitAllowsSameNamespaceReusingItsOwnHost(t *testing.T) {
+	client := fake.NewSimpleClientset(&extensionsv1beta1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-old", Namespace: "team-a"},
+		Spec: extensionsv1beta1.IngressSpec{
+			Rules: []extensionsv1beta1.IngressRule{{Host: "app.example.com"}},
+		},
+	})
+	a := newTestAuthorizer()
+
+	ingress := &extensions.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-new", Namespace: "team-a"},
+		Spec:       extensions.IngressSpec{Rules: []extensions.IngressRule{{Host: "app.example.com"}}},
+	}
+
+	if errs := a.Admit(client, cache.New(time.Minute, time.Minute), ingress); len(errs) != 0 {
+		t.Fatalf("expected no errors for same-namespace reuse, got: %v", errs)
+	}
+}
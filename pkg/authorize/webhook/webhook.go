@@ -0,0 +1,242 @@
+/*
+Copyright 2017 Home Office All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/UKHomeOffice/policy-admission/pkg/api"
+
+	"github.com/patrickmn/go-cache"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/yaml.v2"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Name is the name of the authorizer
+const Name = "webhook"
+
+// defaultTimeout is used when the config does not specify one
+var defaultTimeout = 5 * time.Second
+
+// defaultCacheTTL is used when the config does not specify one
+var defaultCacheTTL = 1 * time.Minute
+
+var (
+	// cacheHitMetric is the number of decisions served from the shared resource cache
+	cacheHitMetric = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "policy_admission_webhook_cache_hit_total",
+		Help: "The total number of webhook authorizer decisions served from cache",
+	})
+	// cacheMissMetric is the number of decisions which required a call to the endpoint
+	cacheMissMetric = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "policy_admission_webhook_cache_miss_total",
+		Help: "The total number of webhook authorizer decisions which required a call to the endpoint",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(cacheHitMetric, cacheMissMetric)
+}
+
+// Config is the configuration for the webhook authorizer
+type Config struct {
+	// URL is the https endpoint the review is posted to
+	URL string `yaml:"url"`
+	// CABundle is the path to a file containing the ca used to verify the endpoint
+	CABundle string `yaml:"ca-bundle"`
+	// ClientCert is the path to a file containing a client certificate
+	ClientCert string `yaml:"client-cert"`
+	// ClientKey is the path to a file containing a client key
+	ClientKey string `yaml:"client-key"`
+	// Timeout is the timeout on the request to the endpoint
+	Timeout time.Duration `yaml:"timeout"`
+	// CacheTTL is how long we cache a decision for, keyed on a hash of the object
+	CacheTTL time.Duration `yaml:"cache-ttl"`
+	// IgnoreNamespaces is a list of namespaces this authorizer should ignore
+	IgnoreNamespaces []string `yaml:"ignore-namespaces"`
+}
+
+// review is the request posted to the webhook endpoint, modelled on a SubjectAccessReview
+type review struct {
+	APIVersion string          `json:"apiVersion"`
+	Kind       string          `json:"kind"`
+	Object     json.RawMessage `json:"object"`
+}
+
+// reviewStatus is the response we expect back from the webhook endpoint
+type reviewStatus struct {
+	Allowed bool   `json:"allowed"`
+	Reason  string `json:"reason"`
+}
+
+// authorizer is used to delegate the admission decision to an external service
+type authorizer struct {
+	config *Config
+	client *http.Client
+}
+
+// cacheKeyPrefix namespaces our cache keys within the shared resource cache
+const cacheKeyPrefix = "webhook/"
+
+// NewFromFile creates and returns a webhook authorizer from a config file
+func NewFromFile(path string) (api.Authorize, error) {
+	config := &Config{
+		Timeout:  defaultTimeout,
+		CacheTTL: defaultCacheTTL,
+	}
+
+	if path != "" {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal(content, config); err != nil {
+			return nil, err
+		}
+	}
+	if config.URL == "" {
+		return nil, fmt.Errorf("no url specified for the %s authorizer", Name)
+	}
+
+	client, err := newHTTPClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &authorizer{
+		config: config,
+		client: client,
+	}, nil
+}
+
+// Admit is responsible for delegating the decision to the external webhook
+func (a *authorizer) Admit(_ kubernetes.Interface, cc *cache.Cache, object v1.Object) field.ErrorList {
+	var errs field.ErrorList
+
+	encoded, err := json.Marshal(object)
+	if err != nil {
+		return append(errs, field.InternalError(field.NewPath("object"), err))
+	}
+
+	key := cacheKeyPrefix + fmt.Sprintf("%x", sha256.Sum256(encoded))
+	if v, found := cc.Get(key); found {
+		cacheHitMetric.Inc()
+
+		if status, ok := v.(*reviewStatus); ok && !status.Allowed {
+			return append(errs, field.Forbidden(field.NewPath("object"), status.Reason))
+		}
+		return errs
+	}
+	cacheMissMetric.Inc()
+
+	status, err := a.review(encoded)
+	if err != nil {
+		return append(errs, field.InternalError(field.NewPath(Name), err))
+	}
+	cc.Set(key, status, a.config.CacheTTL)
+
+	if !status.Allowed {
+		return append(errs, field.Forbidden(field.NewPath("object"), status.Reason))
+	}
+
+	return errs
+}
+
+// review posts the object to the webhook endpoint and parses the response
+func (a *authorizer) review(object []byte) (*reviewStatus, error) {
+	body, err := json.Marshal(&review{
+		APIVersion: "policy-admission.k8s.io/v1",
+		Kind:       "Review",
+		Object:     object,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := a.client.Post(a.config.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response from webhook: %s", resp.Status)
+	}
+
+	status := &reviewStatus{}
+	if err := json.NewDecoder(resp.Body).Decode(status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// FilterOn returns the filter for this authorizer
+func (a *authorizer) FilterOn() *api.Filter {
+	return &api.Filter{
+		IgnoreNamespaces: a.config.IgnoreNamespaces,
+		Kind:             api.FilterAll,
+	}
+}
+
+// Name returns the name of the provider
+func (a *authorizer) Name() string {
+	return Name
+}
+
+// newHTTPClient builds the http client used to talk to the webhook endpoint
+func newHTTPClient(config *Config) (*http.Client, error) {
+	tlsConfig := &tls.Config{}
+
+	if config.CABundle != "" {
+		ca, err := ioutil.ReadFile(config.CABundle)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("unable to parse the ca bundle: %s", config.CABundle)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.ClientCert != "" && config.ClientKey != "" {
+		cert, err := tls.LoadX509KeyPair(config.ClientCert, config.ClientKey)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return &http.Client{
+		Timeout: config.Timeout,
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+		},
+	}, nil
+}
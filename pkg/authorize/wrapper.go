@@ -0,0 +1,188 @@
+/*
+Copyright 2017 Home Office All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authorize
+
+import (
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+
+	"github.com/UKHomeOffice/policy-admission/pkg/api"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/patrickmn/go-cache"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
+)
+
+// configReloadMetric is the number of configuration reload attempts per authorizer
+var configReloadMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "policy_admission_config_reload_total",
+	Help: "The total number of authorizer configuration reload attempts",
+}, []string{"provider", "result"})
+
+func init() {
+	prometheus.MustRegister(configReloadMetric)
+}
+
+// wrapper wraps a authorizer, watching it's config file for changes (and SIGHUP) and
+// atomically swapping the underlying authorizer so in-flight Admit calls see a consistent config
+type wrapper struct {
+	// mutex protects the underlying authorizer
+	mutex sync.RWMutex
+	// name is the name of the wrapped authorizer
+	name string
+	// path is the config file backing the authorizer
+	path string
+	// authorizer is the current, live authorizer
+	authorizer api.Authorize
+}
+
+// newWrapper creates and returns a reloadable authorizer
+func newWrapper(name, path string) (api.Authorize, error) {
+	authorizer, err := newAuthorizer(name, path)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &wrapper{name: name, path: path, authorizer: authorizer}
+
+	if path != "" {
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			return nil, err
+		}
+		// @step: watch the parent directory rather than the file itself - ConfigMap
+		// volumes deliver an update via an atomic symlink swap (a new "..data" target
+		// plus removal of the old one), which would otherwise both miss the change and
+		// permanently drop the watch once the file it pointed at is removed
+		if err := watcher.Add(filepath.Dir(path)); err != nil {
+			return nil, err
+		}
+
+		go w.watch(watcher)
+	}
+
+	signalChannel := make(chan os.Signal, 1)
+	signal.Notify(signalChannel, syscall.SIGHUP)
+	go func() {
+		for range signalChannel {
+			w.Reload()
+		}
+	}()
+
+	return w, nil
+}
+
+// watch listens for filesystem events on the config file's directory and triggers a
+// reload when our file (or the ConfigMap "..data" symlink it may resolve through) changes
+func (w *wrapper) watch(watcher *fsnotify.Watcher) {
+	defer watcher.Close()
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+
+			// @check the event relates to our config file or the ConfigMap "..data"
+			// symlink which is what actually gets swapped on an atomic update
+			name := filepath.Base(event.Name)
+			if name != filepath.Base(w.path) && name != "..data" {
+				continue
+			}
+
+			log.WithFields(log.Fields{
+				"event":    event.Op.String(),
+				"provider": w.name,
+			}).Info("authorizer config file changed, reloading")
+
+			w.Reload()
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			log.WithFields(log.Fields{
+				"error":    err.Error(),
+				"provider": w.name,
+			}).Error("error watching the authorizer config directory")
+		}
+	}
+}
+
+// Reload re-parses the config file and atomically swaps in the new authorizer
+func (w *wrapper) Reload() {
+	authorizer, err := newAuthorizer(w.name, w.path)
+	if err != nil {
+		configReloadMetric.WithLabelValues(w.name, "failed").Inc()
+
+		log.WithFields(log.Fields{
+			"error":    err.Error(),
+			"provider": w.name,
+		}).Error("unable to reload the authorizer config, keeping the previous one")
+
+		return
+	}
+
+	w.mutex.Lock()
+	w.authorizer = authorizer
+	w.mutex.Unlock()
+
+	configReloadMetric.WithLabelValues(w.name, "success").Inc()
+
+	log.WithFields(log.Fields{"provider": w.name}).Info("reloaded the authorizer config")
+}
+
+// current returns the live authorizer under the read lock
+func (w *wrapper) current() api.Authorize {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	return w.authorizer
+}
+
+// Admit defers to the current underlying authorizer
+func (w *wrapper) Admit(client kubernetes.Interface, cc *cache.Cache, object v1.Object) field.ErrorList {
+	return w.current().Admit(client, cc, object)
+}
+
+// Mutate defers to the current underlying authorizer if it implements api.Mutator,
+// otherwise it's a no-op so wrapping a non-mutating authorizer still satisfies api.Mutator
+func (w *wrapper) Mutate(client kubernetes.Interface, cc *cache.Cache, object v1.Object) ([]api.PatchOperation, field.ErrorList) {
+	mutator, ok := w.current().(api.Mutator)
+	if !ok {
+		return nil, nil
+	}
+
+	return mutator.Mutate(client, cc, object)
+}
+
+// FilterOn defers to the current underlying authorizer
+func (w *wrapper) FilterOn() *api.Filter {
+	return w.current().FilterOn()
+}
+
+// Name defers to the current underlying authorizer
+func (w *wrapper) Name() string {
+	return w.current().Name()
+}
@@ -60,6 +60,10 @@ const (
 	actionAccepted = "accept"
 	// The request has cause an error
 	actionErrored = "error"
+	// The request was short-circuited via the bypass list
+	actionBypassed = "bypassed"
+	// The request would have been denied, but the provider is in dry-run mode
+	actionWouldDeny = "would_deny"
 )
 
 // admissionResult is the result of a admission review
@@ -68,6 +72,8 @@ type admissionResult struct {
 	Allowed bool
 	// Object is the which was for review
 	Object metav1.Object
+	// Patches is the combined set of JSON patch operations returned by the authorizers
+	Patches []api.PatchOperation
 	// Response is what the admission response should be
 	Status *metav1.Status
 }
@@ -117,6 +123,22 @@ func (c *Admission) admit(review *admission.AdmissionReview) error {
 
 	review.Response = &admission.AdmissionResponse{Allowed: true}
 
+	// @step: if any of the authorizers returned patches, merge and attach them to the response
+	// (note: AdmissionResponse.Patch is base64 encoded automatically on serialization as it's a []byte)
+	if len(result.Patches) > 0 {
+		patch, err := json.Marshal(result.Patches)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"error": err.Error(),
+			}).Errorf("unable to marshal the patch operations")
+
+			return err
+		}
+		patchType := admission.PatchTypeJSONPatch
+		review.Response.Patch = patch
+		review.Response.PatchType = &patchType
+	}
+
 	log.WithFields(log.Fields{
 		"name":      result.Object.GetName(),
 		"namespace": result.Object.GetNamespace(),
@@ -142,8 +164,11 @@ func (c *Admission) handleAdmissionReview(review *admission.AdmissionReview) (*a
 		Status:  &metav1.Status{},
 	}
 	status := result.Status
+	aggregate := c.config.Mode == ModeAggregate
+	var reasons []string
 
-	// @step: iterate the authorizers and fail on first refusal
+	// @step: iterate the authorizers - on fail-fast we stop on the first refusal, on
+	// aggregate we run every applicable authorizer and combine the denial reasons
 	for _, provider := range c.providers {
 		// @check if this authorizer is listening to this type
 		if provider.FilterOn().Kind != kind {
@@ -170,8 +195,6 @@ func (c *Admission) handleAdmissionReview(review *admission.AdmissionReview) (*a
 		}()
 
 		if len(errs) > 0 {
-			admissionAuthorizerActionMetric.WithLabelValues(provider.Name(), actionDenied).Inc()
-
 			// @check if it's an internal provider error and whether we should skip them
 			skipme := false
 			for _, x := range errs {
@@ -192,17 +215,77 @@ func (c *Admission) handleAdmissionReview(review *admission.AdmissionReview) (*a
 				continue
 			}
 
-			var reasons []string
+			// @check if the provider is running in dry-run mode - log and count the
+			// violation but don't let it fail the admission review
+			if utils.Contained(provider.Name(), c.config.DryRunProviders) {
+				admissionAuthorizerActionMetric.WithLabelValues(provider.Name(), actionWouldDeny).Inc()
+
+				var denied []string
+				for _, x := range errs {
+					denied = append(denied, fmt.Sprintf("%s=%v : %s", x.Field, x.BadValue, x.Detail))
+				}
+				log.WithFields(log.Fields{
+					"name":      object.GetGenerateName(),
+					"namespace": object.GetNamespace(),
+					"provider":  provider.Name(),
+					"reason":    strings.Join(denied, ","),
+				}).Warn("provider is in dry-run mode, not enforcing this denial")
+
+				continue
+			}
+
+			// @step: the denial is actually being enforced - this is the only path that
+			// should count against the real deny metric, distinct from would_deny above
+			admissionAuthorizerActionMetric.WithLabelValues(provider.Name(), actionDenied).Inc()
+
+			result.Allowed = false
+			if !aggregate {
+				var denied []string
+				for _, x := range errs {
+					denied = append(denied, fmt.Sprintf("%s=%v : %s", x.Field, x.BadValue, x.Detail))
+				}
+				status.Message = strings.Join(denied, ",")
+
+				return result, nil
+			}
+
+			// @step: aggregate mode - record the violation, tagged with the offending
+			// provider, and carry on so every applicable authorizer gets a chance to run
 			for _, x := range errs {
-				reasons = append(reasons, fmt.Sprintf("%s=%v : %s", x.Field, x.BadValue, x.Detail))
+				reasons = append(reasons, fmt.Sprintf("%s: %s=%v : %s", provider.Name(), x.Field, x.BadValue, x.Detail))
 			}
+
+			continue
+		}
+
+		admissionAuthorizerActionMetric.WithLabelValues(provider.Name(), actionAccepted).Inc()
+
+		// @step: give the authorizer the chance to mutate the object, if it supports it
+		mutator, ok := provider.(api.Mutator)
+		if !ok {
+			continue
+		}
+		patches, errs := mutator.Mutate(c.client, c.resourceCache, object)
+		if len(errs) > 0 {
 			result.Allowed = false
-			status.Message = strings.Join(reasons, ",")
+			for _, x := range errs {
+				reasons = append(reasons, fmt.Sprintf("%s: %s=%v : %s", provider.Name(), x.Field, x.BadValue, x.Detail))
+			}
+			if !aggregate {
+				status.Message = strings.Join(reasons, ",")
 
-			return result, nil
+				return result, nil
+			}
+
+			continue
 		}
+		result.Patches = append(result.Patches, patches...)
+	}
+
+	if aggregate && len(reasons) > 0 {
+		status.Message = strings.Join(reasons, ",")
 
-		admissionAuthorizerActionMetric.WithLabelValues(provider.Name(), actionAccepted)
+		return result, nil
 	}
 
 	return result, nil
@@ -278,6 +361,11 @@ func New(config *Config, providers []api.Authorize) (*Admission, error) {
 	}
 	engine.HideBanner = true
 	engine.POST("/", c.admitHandler)
+	// @note: also route any other path to the same handler - this lets operators point
+	// distinct webhook configurations (i.e. per bypass-sensitive component) at paths of
+	// their choosing and have AlwaysAllowPaths actually be reachable for them
+	engine.POST("/*", c.admitHandler)
+	engine.POST("/reload", c.reloadHandler)
 	engine.GET("/health", c.healthHandler)
 	if config.EnableMetrics {
 		engine.GET("/metrics", func(ctx echo.Context) error {
@@ -0,0 +1,53 @@
+/*
+Copyright 2017 Home Office All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+const (
+	// ModeFailFast stops on the first authorizer which denies the request
+	ModeFailFast = "fail-fast"
+	// ModeAggregate runs every applicable authorizer and combines their denial reasons
+	ModeAggregate = "aggregate"
+)
+
+// Config is the configuration for the service
+type Config struct {
+	// AlwaysAllowPaths is a list of http path prefixes which are always allowed, bypassing
+	// every authorizer - used for cluster components which must never be blocked
+	AlwaysAllowPaths []string
+	// AlwaysAllowUsers is a list of usernames which are always allowed, bypassing every authorizer
+	AlwaysAllowUsers []string
+	// DryRunProviders is a list of authorizer names whose denials are logged and counted but
+	// do not fail the admission review, used to roll out new policies safely
+	DryRunProviders []string
+	// EnableEvents indicates we should raise kubernetes events on denials
+	EnableEvents bool
+	// EnableLogging indicates we should log the admission requests
+	EnableLogging bool
+	// EnableMetrics indicates we should expose a /metrics endpoint
+	EnableMetrics bool
+	// Listen is the interface the service should listen on
+	Listen string
+	// Mode controls whether we stop on the first authorizer denial (fail-fast) or
+	// run every applicable authorizer and combine their denial reasons (aggregate)
+	Mode string
+	// Namespace is the namespace to create denial events in
+	Namespace string
+	// TLSCert is the path to a file containing the tls certificate
+	TLSCert string
+	// TLSKey is the path to a file containing the tls key
+	TLSKey string
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2017 Home Office All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/UKHomeOffice/policy-admission/pkg/utils"
+
+	"github.com/labstack/echo"
+	log "github.com/sirupsen/logrus"
+	admission "k8s.io/api/admission/v1beta1"
+)
+
+// admitHandler is the http handler which receives the admission review from the apiserver
+func (c *Admission) admitHandler(ctx echo.Context) error {
+	review := &admission.AdmissionReview{}
+	if err := ctx.Bind(review); err != nil {
+		return ctx.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	// @check if the request is on the bypass list and if so skip all the authorizers
+	if c.isBypassed(ctx.Request().URL.Path, review) {
+		admissionTotalMetric.WithLabelValues(actionBypassed).Inc()
+
+		fields := log.Fields{"path": ctx.Request().URL.Path}
+		if review.Request != nil {
+			fields["namespace"] = review.Request.Namespace
+			fields["user"] = review.Request.UserInfo.Username
+		}
+		log.WithFields(fields).Info("request is on the bypass list, skipping all authorizers")
+
+		review.Response = &admission.AdmissionResponse{Allowed: true}
+
+		return ctx.JSON(http.StatusOK, review)
+	}
+
+	if err := c.admit(review); err != nil {
+		return ctx.JSON(http.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(http.StatusOK, review)
+}
+
+// isBypassed checks whether the request path or the requesting user is configured to always be allowed
+func (c *Admission) isBypassed(path string, review *admission.AdmissionReview) bool {
+	for _, x := range c.config.AlwaysAllowPaths {
+		if strings.HasPrefix(path, x) {
+			return true
+		}
+	}
+
+	if review.Request != nil && utils.Contained(review.Request.UserInfo.Username, c.config.AlwaysAllowUsers) {
+		return true
+	}
+
+	return false
+}
+
+// healthHandler is the http handler for the health endpoint
+func (c *Admission) healthHandler(ctx echo.Context) error {
+	return ctx.NoContent(http.StatusOK)
+}
+
+// reloader is implemented by authorizers which support being reloaded on demand
+type reloader interface {
+	Reload()
+}
+
+// reloadHandler triggers a reload of every reloadable authorizer, it's only reachable
+// if a prefix in AlwaysAllowPaths matches "/reload" as it carries no authentication of its own
+func (c *Admission) reloadHandler(ctx echo.Context) error {
+	if !c.isBypassed(ctx.Request().URL.Path, &admission.AdmissionReview{}) {
+		return ctx.NoContent(http.StatusForbidden)
+	}
+
+	for _, provider := range c.providers {
+		if x, ok := provider.(reloader); ok {
+			x.Reload()
+		}
+	}
+
+	return ctx.NoContent(http.StatusOK)
+}